@@ -0,0 +1,130 @@
+//go:build boltdb
+
+package saga
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var sagaBucket = []byte("sagas")
+
+// BoltSagaLog is a SagaLog backed by a BoltDB file. Each saga gets its own
+// nested bucket keyed by sagaID, holding its messages in append order under
+// monotonically increasing sequence keys, so GetMessages replays them in
+// the order they were written.
+type BoltSagaLog struct {
+	db *bolt.DB
+}
+
+// NewBoltSagaLog opens (creating if necessary) the top-level bucket used to
+// store sagas in db.
+func NewBoltSagaLog(db *bolt.DB) (*BoltSagaLog, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sagaBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt saga log: %w", err)
+	}
+	return &BoltSagaLog{db: db}, nil
+}
+
+// StartSaga implements SagaLog.
+func (l *BoltSagaLog) StartSaga(sagaID string, meta []byte) error {
+	return l.db.Update(func(tx *bolt.Tx) error {
+		sagas := tx.Bucket(sagaBucket)
+		if sagas.Bucket([]byte(sagaID)) != nil {
+			return fmt.Errorf("saga %s already started", sagaID)
+		}
+		b, err := sagas.CreateBucket([]byte(sagaID))
+		if err != nil {
+			return err
+		}
+		return appendMessage(b, newMessage(sagaID, MsgStartSaga, "", meta))
+	})
+}
+
+// LogMessage implements SagaLog.
+func (l *BoltSagaLog) LogMessage(msg SagaMessage) error {
+	return l.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sagaBucket).Bucket([]byte(msg.SagaID))
+		if b == nil {
+			return fmt.Errorf("saga %s not started", msg.SagaID)
+		}
+		return appendMessage(b, msg)
+	})
+}
+
+// GetMessages implements SagaLog.
+func (l *BoltSagaLog) GetMessages(sagaID string) ([]SagaMessage, error) {
+	var messages []SagaMessage
+	err := l.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sagaBucket).Bucket([]byte(sagaID))
+		if b == nil {
+			return fmt.Errorf("saga %s not found", sagaID)
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var msg SagaMessage
+			if err := gob.NewDecoder(bytes.NewReader(v)).Decode(&msg); err != nil {
+				return err
+			}
+			messages = append(messages, msg)
+			return nil
+		})
+	})
+	return messages, err
+}
+
+// GetActiveSagas implements SagaLog.
+func (l *BoltSagaLog) GetActiveSagas() ([]string, error) {
+	var ids []string
+	err := l.db.View(func(tx *bolt.Tx) error {
+		sagas := tx.Bucket(sagaBucket)
+		return sagas.ForEach(func(name, v []byte) error {
+			if v != nil {
+				// Not a nested saga bucket.
+				return nil
+			}
+			b := sagas.Bucket(name)
+			ended := false
+			if err := b.ForEach(func(_, v []byte) error {
+				var msg SagaMessage
+				if err := gob.NewDecoder(bytes.NewReader(v)).Decode(&msg); err != nil {
+					return err
+				}
+				if msg.Type == MsgEndSaga || msg.Type == MsgAbortSaga {
+					ended = true
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			if !ended {
+				ids = append(ids, string(name))
+			}
+			return nil
+		})
+	})
+	return ids, err
+}
+
+// appendMessage writes msg under the bucket's next sequence number so
+// ForEach replays messages in the order they were logged.
+func appendMessage(b *bolt.Bucket, msg SagaMessage) error {
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(msg); err != nil {
+		return err
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return b.Put(key, buf.Bytes())
+}
@@ -0,0 +1,45 @@
+package saga
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryBroker is a Broker that dispatches published messages directly
+// to subscribed handlers in the same process. Publish calls each
+// subscriber synchronously, in subscription order, and returns the first
+// handler error it sees.
+type InMemoryBroker struct {
+	mu          sync.Mutex
+	subscribers map[string][]func(ctx context.Context, msg []byte) error
+}
+
+// NewInMemoryBroker creates an empty InMemoryBroker.
+func NewInMemoryBroker() *InMemoryBroker {
+	return &InMemoryBroker{
+		subscribers: make(map[string][]func(ctx context.Context, msg []byte) error),
+	}
+}
+
+// Publish implements Broker.
+func (b *InMemoryBroker) Publish(ctx context.Context, topic string, msg []byte) error {
+	b.mu.Lock()
+	handlers := append([]func(context.Context, []byte) error(nil), b.subscribers[topic]...)
+	b.mu.Unlock()
+
+	var firstErr error
+	for _, handler := range handlers {
+		if err := handler(ctx, msg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Subscribe implements Broker.
+func (b *InMemoryBroker) Subscribe(ctx context.Context, topic string, handler func(ctx context.Context, msg []byte) error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[topic] = append(b.subscribers[topic], handler)
+	return nil
+}
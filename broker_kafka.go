@@ -0,0 +1,53 @@
+//go:build kafka
+
+package saga
+
+import (
+	"context"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// KafkaBroker is a Broker backed by Kafka. Topic is used directly as the
+// Kafka topic name; brokers is the bootstrap broker list shared by every
+// reader/writer it creates.
+type KafkaBroker struct {
+	brokers []string
+}
+
+// NewKafkaBroker creates a KafkaBroker that dials brokers for every topic
+// it publishes or subscribes to.
+func NewKafkaBroker(brokers []string) *KafkaBroker {
+	return &KafkaBroker{brokers: brokers}
+}
+
+// Publish implements Broker.
+func (b *KafkaBroker) Publish(ctx context.Context, topic string, msg []byte) error {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(b.brokers...),
+		Topic:    topic,
+		Balancer: &kafka.LeastBytes{},
+	}
+	defer writer.Close()
+	return writer.WriteMessages(ctx, kafka.Message{Value: msg})
+}
+
+// Subscribe implements Broker.
+func (b *KafkaBroker) Subscribe(ctx context.Context, topic string, handler func(ctx context.Context, msg []byte) error) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: b.brokers,
+		Topic:   topic,
+	})
+
+	go func() {
+		defer reader.Close()
+		for {
+			m, err := reader.ReadMessage(ctx)
+			if err != nil {
+				return
+			}
+			_ = handler(ctx, m.Value)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,34 @@
+//go:build nats
+
+package saga
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSBroker is a Broker backed by a NATS connection. Each Subscribe call
+// opens its own subscription, so ordering across subscribers on the same
+// topic is whatever NATS delivers.
+type NATSBroker struct {
+	conn *nats.Conn
+}
+
+// NewNATSBroker wraps an already-connected *nats.Conn as a Broker.
+func NewNATSBroker(conn *nats.Conn) *NATSBroker {
+	return &NATSBroker{conn: conn}
+}
+
+// Publish implements Broker.
+func (b *NATSBroker) Publish(ctx context.Context, topic string, msg []byte) error {
+	return b.conn.Publish(topic, msg)
+}
+
+// Subscribe implements Broker.
+func (b *NATSBroker) Subscribe(ctx context.Context, topic string, handler func(ctx context.Context, msg []byte) error) error {
+	_, err := b.conn.Subscribe(topic, func(m *nats.Msg) {
+		_ = handler(ctx, m.Data)
+	})
+	return err
+}
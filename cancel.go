@@ -0,0 +1,28 @@
+package saga
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrManualAbort is the context.Cause recorded when Abort (including the
+// abortFunc returned by New) stops a saga while it's still executing, as
+// opposed to a step failing or the caller's own context expiring.
+var ErrManualAbort = errors.New("saga manually aborted")
+
+// ErrStepFailed is the context.Cause recorded when step Index fails, so
+// sibling steps (DAG mode) and Compensate callbacks can distinguish "a
+// step failed" from a deadline or manual abort via context.Cause(ctx),
+// and decide whether cleanup is still worth attempting.
+type ErrStepFailed struct {
+	Index int
+	Err   error
+}
+
+func (e ErrStepFailed) Error() string {
+	return fmt.Sprintf("step %d failed: %v", e.Index, e.Err)
+}
+
+func (e ErrStepFailed) Unwrap() error {
+	return e.Err
+}
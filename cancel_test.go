@@ -0,0 +1,37 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelCauseOnStepFailure(t *testing.T) {
+	s, _ := saga.New()
+	var causeInCompensate error
+
+	s.AddStep(saga.Step{
+		Name:    "a",
+		Execute: func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error {
+			causeInCompensate = context.Cause(ctx)
+			return nil
+		},
+	})
+	s.AddStep(saga.Step{
+		Name:       "b",
+		Execute:    func(ctx context.Context) error { return errors.New("b failed") },
+		Compensate: func(ctx context.Context) error { return nil },
+	})
+
+	err := s.Execute(context.Background())
+	require.Error(t, err)
+
+	var stepFailed saga.ErrStepFailed
+	require.ErrorAs(t, causeInCompensate, &stepFailed)
+	assert.Equal(t, 1, stepFailed.Index)
+}
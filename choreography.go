@@ -0,0 +1,188 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// compensateSuffix is appended to a ChoreographyStep's Topic to derive the
+// topic its compensation listens on.
+const compensateSuffix = ".compensate"
+
+// Broker is the pub/sub abstraction a Choreographer drives itself
+// through. Implementations must support multiple subscribers per topic.
+type Broker interface {
+	// Publish sends msg to every subscriber of topic.
+	Publish(ctx context.Context, topic string, msg []byte) error
+	// Subscribe registers handler to be called for every message
+	// published to topic. A handler error does not unsubscribe it.
+	Subscribe(ctx context.Context, topic string, handler func(ctx context.Context, msg []byte) error) error
+}
+
+// Event is the envelope a Choreographer publishes and consumes: it
+// correlates messages to a saga via SagaID, the same ID a SagaLog uses.
+type Event struct {
+	SagaID  string
+	Payload []byte
+}
+
+func decodeEvent(raw []byte) (Event, error) {
+	var evt Event
+	if err := json.Unmarshal(raw, &evt); err != nil {
+		return Event{}, fmt.Errorf("decode saga event: %w", err)
+	}
+	return evt, nil
+}
+
+func encodeEvent(evt Event) []byte {
+	raw, err := json.Marshal(evt)
+	if err != nil {
+		// Event's fields are all trivially serializable; this can't fail.
+		panic(fmt.Sprintf("encode saga event: %v", err))
+	}
+	return raw
+}
+
+// ChoreographyStep is one participant in an event-driven saga: it reacts
+// to an Event published on Topic, does its local work, and publishes to
+// OnSuccess (or OnFailure) by name to hand off to the next participant.
+// Compensate undoes the step's local work when a later step in the chain
+// reports failure; it is invoked on the topic Topic+".compensate".
+type ChoreographyStep struct {
+	Name  string
+	Topic string
+
+	Handle     func(ctx context.Context, payload []byte) (result []byte, err error)
+	Compensate func(ctx context.Context, payload []byte) error
+
+	// OnSuccess names the step to hand off to after Handle succeeds. Empty
+	// means this is the last forward step in the saga.
+	OnSuccess string
+	// OnFailure names the step whose compensation to trigger after Handle
+	// fails (typically the step immediately before this one). Empty means
+	// there's nothing earlier left to compensate.
+	OnFailure string
+}
+
+// Choreographer runs a saga as a chain of ChoreographySteps reacting to
+// Broker events rather than being called directly like Saga's steps are.
+// Progress is persisted to a SagaLog with the same message types as the
+// orchestrated path, but each message's StepID is the step's Name rather
+// than its index. Recover, which takes []Step and parses StepIDs as
+// indices, does not resume a choreography saga.
+type Choreographer struct {
+	broker Broker
+	log    SagaLog
+	steps  map[string]ChoreographyStep
+}
+
+// NewChoreographer builds a Choreographer over the given steps, keyed by
+// their Name.
+func NewChoreographer(broker Broker, log SagaLog, steps ...ChoreographyStep) *Choreographer {
+	m := make(map[string]ChoreographyStep, len(steps))
+	for _, step := range steps {
+		m[step.Name] = step
+	}
+	return &Choreographer{broker: broker, log: log, steps: m}
+}
+
+// Start subscribes every step to its trigger topic (and, if it has a
+// Compensate, to its compensate topic). Handling happens asynchronously as
+// the Broker delivers messages; Start itself only registers subscriptions.
+func (c *Choreographer) Start(ctx context.Context) error {
+	for _, step := range c.steps {
+		step := step
+
+		if err := c.broker.Subscribe(ctx, step.Topic, func(ctx context.Context, msg []byte) error {
+			return c.handleForward(ctx, step, msg)
+		}); err != nil {
+			return fmt.Errorf("subscribe %s: %w", step.Topic, err)
+		}
+
+		if step.Compensate != nil {
+			compensateTopic := step.Topic + compensateSuffix
+			if err := c.broker.Subscribe(ctx, compensateTopic, func(ctx context.Context, msg []byte) error {
+				return c.handleCompensate(ctx, step, msg)
+			}); err != nil {
+				return fmt.Errorf("subscribe %s: %w", compensateTopic, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Publish starts (or advances) a choreography saga by publishing an event
+// to step's trigger topic.
+func (c *Choreographer) Publish(ctx context.Context, sagaID, stepName string, payload []byte) error {
+	step, ok := c.steps[stepName]
+	if !ok {
+		return fmt.Errorf("no step named %q", stepName)
+	}
+	return c.broker.Publish(ctx, step.Topic, encodeEvent(Event{SagaID: sagaID, Payload: payload}))
+}
+
+func (c *Choreographer) ensureStarted(sagaID string) {
+	if _, err := c.log.GetMessages(sagaID); err != nil {
+		_ = c.log.StartSaga(sagaID, nil)
+	}
+}
+
+func (c *Choreographer) handleForward(ctx context.Context, step ChoreographyStep, raw []byte) error {
+	evt, err := decodeEvent(raw)
+	if err != nil {
+		return err
+	}
+	c.ensureStarted(evt.SagaID)
+	_ = c.log.LogMessage(newAttemptMessage(evt.SagaID, MsgStartTask, step.Name, 1))
+
+	result, err := step.Handle(ctx, evt.Payload)
+	if err != nil {
+		if step.OnFailure == "" {
+			_ = c.log.LogMessage(newMessage(evt.SagaID, MsgAbortSaga, "", nil))
+			return err
+		}
+		prev, ok := c.steps[step.OnFailure]
+		if !ok {
+			return fmt.Errorf("step %q has unknown OnFailure %q", step.Name, step.OnFailure)
+		}
+		return c.broker.Publish(ctx, prev.Topic+compensateSuffix, encodeEvent(Event{SagaID: evt.SagaID, Payload: evt.Payload}))
+	}
+
+	_ = c.log.LogMessage(newMessage(evt.SagaID, MsgEndTask, step.Name, result))
+
+	if step.OnSuccess == "" {
+		_ = c.log.LogMessage(newMessage(evt.SagaID, MsgEndSaga, "", nil))
+		return nil
+	}
+
+	next, ok := c.steps[step.OnSuccess]
+	if !ok {
+		return fmt.Errorf("step %q has unknown OnSuccess %q", step.Name, step.OnSuccess)
+	}
+	return c.broker.Publish(ctx, next.Topic, encodeEvent(Event{SagaID: evt.SagaID, Payload: result}))
+}
+
+func (c *Choreographer) handleCompensate(ctx context.Context, step ChoreographyStep, raw []byte) error {
+	evt, err := decodeEvent(raw)
+	if err != nil {
+		return err
+	}
+	_ = c.log.LogMessage(newAttemptMessage(evt.SagaID, MsgStartCompensate, step.Name, 1))
+
+	if err := step.Compensate(ctx, evt.Payload); err != nil {
+		return err
+	}
+	_ = c.log.LogMessage(newMessage(evt.SagaID, MsgEndCompensate, step.Name, nil))
+
+	if step.OnFailure == "" {
+		_ = c.log.LogMessage(newMessage(evt.SagaID, MsgAbortSaga, "", nil))
+		return nil
+	}
+
+	prev, ok := c.steps[step.OnFailure]
+	if !ok {
+		return fmt.Errorf("step %q has unknown OnFailure %q", step.Name, step.OnFailure)
+	}
+	return c.broker.Publish(ctx, prev.Topic+compensateSuffix, encodeEvent(Event{SagaID: evt.SagaID, Payload: evt.Payload}))
+}
@@ -0,0 +1,102 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChoreographerHappyPath(t *testing.T) {
+	broker := saga.NewInMemoryBroker()
+	log := saga.NewInMemorySagaLog()
+
+	var mu sync.Mutex
+	var ran []string
+
+	choreo := saga.NewChoreographer(broker, log,
+		saga.ChoreographyStep{
+			Name:  "reserve",
+			Topic: "order.reserve",
+			Handle: func(ctx context.Context, payload []byte) ([]byte, error) {
+				mu.Lock()
+				ran = append(ran, "reserve")
+				mu.Unlock()
+				return payload, nil
+			},
+			Compensate: func(ctx context.Context, payload []byte) error { return nil },
+			OnSuccess:  "charge",
+		},
+		saga.ChoreographyStep{
+			Name:  "charge",
+			Topic: "order.charge",
+			Handle: func(ctx context.Context, payload []byte) ([]byte, error) {
+				mu.Lock()
+				ran = append(ran, "charge")
+				mu.Unlock()
+				return payload, nil
+			},
+			Compensate: func(ctx context.Context, payload []byte) error { return nil },
+			OnFailure:  "reserve",
+		},
+	)
+
+	require.NoError(t, choreo.Start(context.Background()))
+	require.NoError(t, choreo.Publish(context.Background(), "saga-1", "reserve", []byte("order-1")))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"reserve", "charge"}, ran)
+
+	messages, err := log.GetMessages("saga-1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, messages)
+}
+
+func TestChoreographerCompensatesOnFailure(t *testing.T) {
+	broker := saga.NewInMemoryBroker()
+	log := saga.NewInMemorySagaLog()
+
+	var mu sync.Mutex
+	var compensated []string
+
+	choreo := saga.NewChoreographer(broker, log,
+		saga.ChoreographyStep{
+			Name:  "reserve",
+			Topic: "order.reserve",
+			Handle: func(ctx context.Context, payload []byte) ([]byte, error) {
+				return payload, nil
+			},
+			Compensate: func(ctx context.Context, payload []byte) error {
+				mu.Lock()
+				compensated = append(compensated, "reserve")
+				mu.Unlock()
+				return nil
+			},
+			OnSuccess: "charge",
+		},
+		saga.ChoreographyStep{
+			Name:  "charge",
+			Topic: "order.charge",
+			Handle: func(ctx context.Context, payload []byte) ([]byte, error) {
+				return nil, errors.New("card declined")
+			},
+			Compensate: func(ctx context.Context, payload []byte) error { return nil },
+			OnFailure:  "reserve",
+		},
+	)
+
+	require.NoError(t, choreo.Start(context.Background()))
+	require.NoError(t, choreo.Publish(context.Background(), "saga-2", "reserve", []byte("order-2")))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(compensated) == 1
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,201 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// completedInReverseOrder returns the currently completed step indices
+// sorted by s.order, reversed, so callers can undo them last-to-first
+// regardless of whether they completed sequentially or concurrently.
+func (s *sagaImpl) completedInReverseOrder() []int {
+	order := s.order
+	if order == nil {
+		order = make([]int, len(s.steps))
+		for i := range s.steps {
+			order[i] = i
+		}
+	}
+
+	position := make(map[int]int, len(order))
+	for pos, idx := range order {
+		position[idx] = pos
+	}
+
+	sorted := make([]int, len(s.completedSteps))
+	copy(sorted, s.completedSteps)
+	sort.Slice(sorted, func(a, b int) bool {
+		return position[sorted[a]] > position[sorted[b]]
+	})
+	return sorted
+}
+
+// hasDependencies reports whether any step in steps declares DependsOn,
+// i.e. whether the saga needs DAG scheduling rather than the plain
+// sequential path.
+func hasDependencies(steps []Step) bool {
+	for _, step := range steps {
+		if len(step.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDependencyGraph resolves each step's DependsOn names to indices
+// and returns, for every step index, the list of step indices it depends
+// on, plus the saga's topological order. It errors on an unknown
+// dependency name or a dependency cycle.
+func resolveDependencyGraph(steps []Step) (dependsOn [][]int, order []int, err error) {
+	nameToIndex := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if step.Name == "" {
+			continue
+		}
+		if _, dup := nameToIndex[step.Name]; dup {
+			return nil, nil, fmt.Errorf("duplicate step name %q", step.Name)
+		}
+		nameToIndex[step.Name] = i
+	}
+
+	dependsOn = make([][]int, len(steps))
+	indegree := make([]int, len(steps))
+	dependents := make([][]int, len(steps))
+
+	for i, step := range steps {
+		for _, dep := range step.DependsOn {
+			j, ok := nameToIndex[dep]
+			if !ok {
+				return nil, nil, fmt.Errorf("step %q depends on unknown step %q", step.Name, dep)
+			}
+			dependsOn[i] = append(dependsOn[i], j)
+			dependents[j] = append(dependents[j], i)
+			indegree[i]++
+		}
+	}
+
+	queue := make([]int, 0, len(steps))
+	for i, d := range indegree {
+		if d == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	order = make([]int, 0, len(steps))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		order = append(order, i)
+		for _, dep := range dependents[i] {
+			indegree[dep]--
+			if indegree[dep] == 0 {
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	if len(order) != len(steps) {
+		return nil, nil, fmt.Errorf("saga steps form a dependency cycle")
+	}
+
+	return dependsOn, order, nil
+}
+
+// stepOutcome carries a failed step's index and error back to the
+// scheduler.
+type stepOutcome struct {
+	index int
+	err   error
+}
+
+// executeGraph runs steps as a DAG: a step becomes runnable once every
+// step named in its DependsOn has completed, and independent steps run
+// concurrently. Unlike before, s.mu is NOT held by the caller (Execute) for
+// the duration, since a concurrent Abort needs it to signal cancellation;
+// shared state here (s.completedSteps) is protected by completedMu instead.
+func (s *sagaImpl) executeGraph(ctx context.Context, dependsOn [][]int, order []int) error {
+	n := len(s.steps)
+	dependents := make([][]int, n)
+	for i, deps := range dependsOn {
+		for _, d := range deps {
+			dependents[d] = append(dependents[d], i)
+		}
+	}
+
+	done := make([]chan struct{}, n)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	outcomes := make(chan stepOutcome, n)
+	var completedMu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+
+			for _, dep := range dependsOn[i] {
+				select {
+				case <-done[dep]:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if err := s.executeStep(ctx, i); err != nil {
+				outcomes <- stepOutcome{index: i, err: err}
+				if s.cancel != nil {
+					s.cancel(ErrStepFailed{Index: i, Err: err})
+				}
+				return
+			}
+
+			completedMu.Lock()
+			s.completedSteps = append(s.completedSteps, i)
+			completedMu.Unlock()
+		}(i)
+	}
+
+	wg.Wait()
+	close(outcomes)
+
+	var failed *stepOutcome
+	for outcome := range outcomes {
+		o := outcome
+		if failed == nil {
+			failed = &o
+		}
+	}
+
+	if failed != nil {
+		if s.isPastPivot(failed.index) {
+			return failed.err
+		}
+		return s.compensate(ctx, -1, failed.err)
+	}
+
+	if cause := context.Cause(ctx); cause != nil {
+		// No step itself failed, but something (e.g. a manual Abort)
+		// cancelled ctx while steps were still pending.
+		if s.pivotCompleted() {
+			// The pivot already committed: it and anything before it must
+			// not be compensated, only retried forward, so there is
+			// nothing left for us to safely undo.
+			return cause
+		}
+		return s.compensate(ctx, -1, cause)
+	}
+
+	return nil
+}
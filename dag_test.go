@@ -0,0 +1,170 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSagaDependencyGraph(t *testing.T) {
+	t.Run("independent steps run concurrently", func(t *testing.T) {
+		s, _ := saga.New()
+		var mu sync.Mutex
+		var started []string
+
+		record := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			started = append(started, name)
+		}
+
+		release := make(chan struct{})
+
+		s.AddStep(saga.Step{
+			Name: "a",
+			Execute: func(ctx context.Context) error {
+				record("a")
+				<-release
+				return nil
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+		s.AddStep(saga.Step{
+			Name: "b",
+			Execute: func(ctx context.Context) error {
+				record("b")
+				<-release
+				return nil
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+		// c depends on both a and b purely to put the saga into DAG
+		// scheduling (hasDependencies requires at least one DependsOn
+		// somewhere); a and b declare no dependency on each other, so
+		// they should still start concurrently.
+		s.AddStep(saga.Step{
+			Name:       "c",
+			DependsOn:  []string{"a", "b"},
+			Execute:    func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		done := make(chan error, 1)
+		go func() { done <- s.Execute(context.Background()) }()
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(started) == 2
+		}, time.Second, 5*time.Millisecond, "both independent steps should have started")
+
+		close(release)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("dependent step waits for its dependency", func(t *testing.T) {
+		s, _ := saga.New()
+		order := []string{}
+		var mu sync.Mutex
+
+		s.AddStep(saga.Step{
+			Name: "first",
+			Execute: func(ctx context.Context) error {
+				time.Sleep(20 * time.Millisecond)
+				mu.Lock()
+				order = append(order, "first")
+				mu.Unlock()
+				return nil
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+		s.AddStep(saga.Step{
+			Name:      "second",
+			DependsOn: []string{"first"},
+			Execute: func(ctx context.Context) error {
+				mu.Lock()
+				order = append(order, "second")
+				mu.Unlock()
+				return nil
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		require.NoError(t, s.Execute(context.Background()))
+		assert.Equal(t, []string{"first", "second"}, order)
+	})
+
+	t.Run("compensates completed steps in reverse topological order on failure", func(t *testing.T) {
+		s, _ := saga.New()
+		var mu sync.Mutex
+		compensated := []string{}
+
+		s.AddStep(saga.Step{
+			Name:    "a",
+			Execute: func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error {
+				mu.Lock()
+				compensated = append(compensated, "a")
+				mu.Unlock()
+				return nil
+			},
+		})
+		s.AddStep(saga.Step{
+			Name:      "b",
+			DependsOn: []string{"a"},
+			Execute: func(ctx context.Context) error {
+				return errors.New("b failed")
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, []string{"a"}, compensated)
+	})
+
+	t.Run("unknown dependency is rejected", func(t *testing.T) {
+		s, _ := saga.New()
+		s.AddStep(saga.Step{
+			Name:      "a",
+			DependsOn: []string{"missing"},
+			Execute:   func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error {
+				return nil
+			},
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown step")
+	})
+
+	t.Run("no dependencies preserves sequential behavior", func(t *testing.T) {
+		s, _ := saga.New()
+		order := []string{}
+
+		s.AddStep(saga.Step{
+			Execute: func(ctx context.Context) error {
+				order = append(order, "step1")
+				return nil
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+		s.AddStep(saga.Step{
+			Execute: func(ctx context.Context) error {
+				order = append(order, "step2")
+				return nil
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		require.NoError(t, s.Execute(context.Background()))
+		assert.Equal(t, []string{"step1", "step2"}, order)
+	})
+}
@@ -0,0 +1,29 @@
+package saga
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+)
+
+// newSagaID generates a random ID for a saga that wasn't given one
+// explicitly via WithSagaID.
+func newSagaID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand failing is effectively unrecoverable, but a saga ID
+		// collision is preferable to a panic here.
+		return "saga-" + strconv.FormatInt(int64(len(b)), 36)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// stepID returns the log identifier for the step at the given index.
+func stepID(index int) string {
+	return strconv.Itoa(index)
+}
+
+// stepIndexFromID reverses stepID.
+func stepIndexFromID(id string) (int, error) {
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,166 @@
+package saga
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MessageType identifies what a SagaMessage records in the saga log.
+type MessageType int
+
+// Message types recorded over the lifetime of a saga, modeled on the
+// Scoot sagalog: a saga starts, each step starts and ends (forward or
+// compensating), and the saga eventually ends or is aborted.
+const (
+	MsgStartSaga MessageType = iota
+	MsgStartTask
+	MsgEndTask
+	MsgStartCompensate
+	MsgEndCompensate
+	MsgEndSaga
+	MsgAbortSaga
+)
+
+func (t MessageType) String() string {
+	switch t {
+	case MsgStartSaga:
+		return "StartSaga"
+	case MsgStartTask:
+		return "StartTask"
+	case MsgEndTask:
+		return "EndTask"
+	case MsgStartCompensate:
+		return "StartCompensate"
+	case MsgEndCompensate:
+		return "EndCompensate"
+	case MsgEndSaga:
+		return "EndSaga"
+	case MsgAbortSaga:
+		return "AbortSaga"
+	default:
+		return fmt.Sprintf("MessageType(%d)", int(t))
+	}
+}
+
+// SagaMessage is a single durable log entry. StepID identifies the step a
+// task/compensate message applies to; it is empty for saga-level messages
+// (StartSaga, EndSaga, AbortSaga). Attempt is the 1-based retry attempt
+// number for StartTask/StartCompensate messages produced under a
+// StepPolicy/CompensatePolicy; it is always 1 for a step with no policy.
+type SagaMessage struct {
+	SagaID    string
+	Type      MessageType
+	StepID    string
+	Attempt   int
+	Result    []byte
+	Meta      []byte
+	Timestamp time.Time
+}
+
+func newMessage(sagaID string, t MessageType, stepID string, result []byte) SagaMessage {
+	return SagaMessage{
+		SagaID:    sagaID,
+		Type:      t,
+		StepID:    stepID,
+		Attempt:   1,
+		Result:    result,
+		Timestamp: time.Now(),
+	}
+}
+
+func newAttemptMessage(sagaID string, t MessageType, stepID string, attempt int) SagaMessage {
+	msg := newMessage(sagaID, t, stepID, nil)
+	msg.Attempt = attempt
+	return msg
+}
+
+// SagaLog is the durable write-ahead log behind a Saga. Implementations
+// must make LogMessage safe to call concurrently and must preserve message
+// order per saga, since Recover replays messages in the order returned by
+// GetMessages.
+type SagaLog interface {
+	// StartSaga records the creation of a new saga and its opaque metadata.
+	StartSaga(sagaID string, meta []byte) error
+	// LogMessage appends a message to the log for msg.SagaID.
+	LogMessage(msg SagaMessage) error
+	// GetMessages returns every message logged for sagaID, in log order.
+	GetMessages(sagaID string) ([]SagaMessage, error)
+	// GetActiveSagas returns the IDs of sagas that have been started but
+	// have not yet logged an EndSaga or AbortSaga message.
+	GetActiveSagas() ([]string, error)
+}
+
+// InMemorySagaLog is a SagaLog backed by an in-process map. It is useful
+// for tests and for callers that don't need crash recovery, but it loses
+// all history when the process exits.
+type InMemorySagaLog struct {
+	mu       sync.Mutex
+	messages map[string][]SagaMessage
+	active   map[string]bool
+}
+
+// NewInMemorySagaLog creates an empty InMemorySagaLog.
+func NewInMemorySagaLog() *InMemorySagaLog {
+	return &InMemorySagaLog{
+		messages: make(map[string][]SagaMessage),
+		active:   make(map[string]bool),
+	}
+}
+
+// StartSaga implements SagaLog.
+func (l *InMemorySagaLog) StartSaga(sagaID string, meta []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.messages[sagaID]; exists {
+		return fmt.Errorf("saga %s already started", sagaID)
+	}
+
+	l.messages[sagaID] = []SagaMessage{newMessage(sagaID, MsgStartSaga, "", meta)}
+	l.active[sagaID] = true
+	return nil
+}
+
+// LogMessage implements SagaLog.
+func (l *InMemorySagaLog) LogMessage(msg SagaMessage) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.messages[msg.SagaID]; !exists {
+		return fmt.Errorf("saga %s not started", msg.SagaID)
+	}
+
+	l.messages[msg.SagaID] = append(l.messages[msg.SagaID], msg)
+	if msg.Type == MsgEndSaga || msg.Type == MsgAbortSaga {
+		delete(l.active, msg.SagaID)
+	}
+	return nil
+}
+
+// GetMessages implements SagaLog.
+func (l *InMemorySagaLog) GetMessages(sagaID string) ([]SagaMessage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	msgs, exists := l.messages[sagaID]
+	if !exists {
+		return nil, fmt.Errorf("saga %s not found", sagaID)
+	}
+
+	out := make([]SagaMessage, len(msgs))
+	copy(out, msgs)
+	return out, nil
+}
+
+// GetActiveSagas implements SagaLog.
+func (l *InMemorySagaLog) GetActiveSagas() ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ids := make([]string, 0, len(l.active))
+	for id := range l.active {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
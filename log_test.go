@@ -0,0 +1,81 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemorySagaLog(t *testing.T) {
+	log := saga.NewInMemorySagaLog()
+
+	require.NoError(t, log.StartSaga("saga-1", []byte("meta")))
+	require.Error(t, log.StartSaga("saga-1", nil), "starting the same saga twice should fail")
+
+	require.NoError(t, log.LogMessage(saga.SagaMessage{SagaID: "saga-1", Type: saga.MsgStartTask, StepID: "0"}))
+	require.NoError(t, log.LogMessage(saga.SagaMessage{SagaID: "saga-1", Type: saga.MsgEndTask, StepID: "0"}))
+
+	active, err := log.GetActiveSagas()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"saga-1"}, active)
+
+	require.NoError(t, log.LogMessage(saga.SagaMessage{SagaID: "saga-1", Type: saga.MsgEndSaga}))
+
+	active, err = log.GetActiveSagas()
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	messages, err := log.GetMessages("saga-1")
+	require.NoError(t, err)
+	assert.Len(t, messages, 4)
+}
+
+func TestRecoverResumesCompensation(t *testing.T) {
+	log := saga.NewInMemorySagaLog()
+	require.NoError(t, log.StartSaga("saga-2", nil))
+	require.NoError(t, log.LogMessage(saga.SagaMessage{SagaID: "saga-2", Type: saga.MsgStartTask, StepID: "0"}))
+	require.NoError(t, log.LogMessage(saga.SagaMessage{SagaID: "saga-2", Type: saga.MsgEndTask, StepID: "0"}))
+	require.NoError(t, log.LogMessage(saga.SagaMessage{SagaID: "saga-2", Type: saga.MsgStartTask, StepID: "1"}))
+	// Crash: no EndTask for step 1, and no EndSaga/AbortSaga was ever written.
+
+	compensated := []int{}
+	steps := []saga.Step{
+		{
+			Execute: func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error {
+				compensated = append(compensated, 0)
+				return nil
+			},
+		},
+		{
+			Execute: func(ctx context.Context) error { return errors.New("never reached") },
+			Compensate: func(ctx context.Context) error {
+				compensated = append(compensated, 1)
+				return nil
+			},
+		},
+	}
+
+	err := saga.Recover(context.Background(), log, "saga-2", steps)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, compensated, "only the completed step should be compensated")
+}
+
+func TestRecoverNoOpOnFinishedSaga(t *testing.T) {
+	log := saga.NewInMemorySagaLog()
+	require.NoError(t, log.StartSaga("saga-3", nil))
+	require.NoError(t, log.LogMessage(saga.SagaMessage{SagaID: "saga-3", Type: saga.MsgEndSaga}))
+
+	called := false
+	steps := []saga.Step{{
+		Execute:    func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error { called = true; return nil },
+	}}
+
+	require.NoError(t, saga.Recover(context.Background(), log, "saga-3", steps))
+	assert.False(t, called, "a saga that already ended should not be compensated again")
+}
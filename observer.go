@@ -0,0 +1,128 @@
+package saga
+
+import "context"
+
+// Observer receives lifecycle callbacks for every attempt a saga makes at
+// a step or its compensation, plus the saga's terminal outcome.
+// Implementations must be safe for concurrent use: DAG-scheduled steps
+// (see resolveDependencyGraph) call these from multiple goroutines.
+type Observer interface {
+	OnStepStart(ctx context.Context, sagaID, stepName string, attempt int)
+	OnStepEnd(ctx context.Context, sagaID, stepName string, attempt int, err error)
+	OnCompensateStart(ctx context.Context, sagaID, stepName string, attempt int)
+	OnCompensateEnd(ctx context.Context, sagaID, stepName string, attempt int, err error)
+	OnSagaEnd(ctx context.Context, sagaID string, err error)
+}
+
+// noopObserver is the default Observer: New always has one to call into
+// so the rest of the package never needs a nil check.
+type noopObserver struct{}
+
+func (noopObserver) OnStepStart(context.Context, string, string, int)            {}
+func (noopObserver) OnStepEnd(context.Context, string, string, int, error)       {}
+func (noopObserver) OnCompensateStart(context.Context, string, string, int)      {}
+func (noopObserver) OnCompensateEnd(context.Context, string, string, int, error) {}
+func (noopObserver) OnSagaEnd(context.Context, string, error)                    {}
+
+// multiObserver fans a callback out to every observer in order, so a saga
+// can have more than one Observer registered (e.g. an OTel observer and a
+// SagaEvent channel at once).
+type multiObserver []Observer
+
+func (m multiObserver) OnStepStart(ctx context.Context, sagaID, stepName string, attempt int) {
+	for _, o := range m {
+		o.OnStepStart(ctx, sagaID, stepName, attempt)
+	}
+}
+
+func (m multiObserver) OnStepEnd(ctx context.Context, sagaID, stepName string, attempt int, err error) {
+	for _, o := range m {
+		o.OnStepEnd(ctx, sagaID, stepName, attempt, err)
+	}
+}
+
+func (m multiObserver) OnCompensateStart(ctx context.Context, sagaID, stepName string, attempt int) {
+	for _, o := range m {
+		o.OnCompensateStart(ctx, sagaID, stepName, attempt)
+	}
+}
+
+func (m multiObserver) OnCompensateEnd(ctx context.Context, sagaID, stepName string, attempt int, err error) {
+	for _, o := range m {
+		o.OnCompensateEnd(ctx, sagaID, stepName, attempt, err)
+	}
+}
+
+func (m multiObserver) OnSagaEnd(ctx context.Context, sagaID string, err error) {
+	for _, o := range m {
+		o.OnSagaEnd(ctx, sagaID, err)
+	}
+}
+
+// addObserver combines existing with next, collapsing into a single
+// multiObserver rather than nesting one every call.
+func addObserver(existing Observer, next Observer) Observer {
+	if multi, ok := existing.(multiObserver); ok {
+		return append(multi, next)
+	}
+	if _, ok := existing.(noopObserver); ok {
+		return next
+	}
+	return multiObserver{existing, next}
+}
+
+// SagaEventType identifies what a SagaEvent reports; it mirrors Observer's callbacks one-for-one.
+type SagaEventType int
+
+const (
+	EventStepStart SagaEventType = iota
+	EventStepEnd
+	EventCompensateStart
+	EventCompensateEnd
+	EventSagaEnd
+)
+
+// SagaEvent is a compact, serializable view of an Observer callback, meant
+// for callers who'd rather drain a channel than implement Observer
+// themselves (e.g. to forward events to logs or a metrics pipeline).
+type SagaEvent struct {
+	Type    SagaEventType
+	SagaID  string
+	Step    string
+	Attempt int
+	Err     error
+}
+
+// channelObserver implements Observer by emitting a SagaEvent per
+// callback. It never blocks: if the channel is full, the event is
+// dropped rather than stalling the saga.
+type channelObserver struct {
+	events chan<- SagaEvent
+}
+
+func (o channelObserver) emit(evt SagaEvent) {
+	select {
+	case o.events <- evt:
+	default:
+	}
+}
+
+func (o channelObserver) OnStepStart(_ context.Context, sagaID, stepName string, attempt int) {
+	o.emit(SagaEvent{Type: EventStepStart, SagaID: sagaID, Step: stepName, Attempt: attempt})
+}
+
+func (o channelObserver) OnStepEnd(_ context.Context, sagaID, stepName string, attempt int, err error) {
+	o.emit(SagaEvent{Type: EventStepEnd, SagaID: sagaID, Step: stepName, Attempt: attempt, Err: err})
+}
+
+func (o channelObserver) OnCompensateStart(_ context.Context, sagaID, stepName string, attempt int) {
+	o.emit(SagaEvent{Type: EventCompensateStart, SagaID: sagaID, Step: stepName, Attempt: attempt})
+}
+
+func (o channelObserver) OnCompensateEnd(_ context.Context, sagaID, stepName string, attempt int, err error) {
+	o.emit(SagaEvent{Type: EventCompensateEnd, SagaID: sagaID, Step: stepName, Attempt: attempt, Err: err})
+}
+
+func (o channelObserver) OnSagaEnd(_ context.Context, sagaID string, err error) {
+	o.emit(SagaEvent{Type: EventSagaEnd, SagaID: sagaID, Err: err})
+}
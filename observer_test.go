@@ -0,0 +1,79 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) OnStepStart(ctx context.Context, sagaID, stepName string, attempt int) {
+	o.events = append(o.events, "start:"+stepName)
+}
+
+func (o *recordingObserver) OnStepEnd(ctx context.Context, sagaID, stepName string, attempt int, err error) {
+	o.events = append(o.events, "end:"+stepName)
+}
+
+func (o *recordingObserver) OnCompensateStart(ctx context.Context, sagaID, stepName string, attempt int) {
+	o.events = append(o.events, "compensate-start:"+stepName)
+}
+
+func (o *recordingObserver) OnCompensateEnd(ctx context.Context, sagaID, stepName string, attempt int, err error) {
+	o.events = append(o.events, "compensate-end:"+stepName)
+}
+
+func (o *recordingObserver) OnSagaEnd(ctx context.Context, sagaID string, err error) {
+	o.events = append(o.events, "saga-end")
+}
+
+func TestObserverReceivesLifecycleCallbacks(t *testing.T) {
+	obs := &recordingObserver{}
+	s, _ := saga.New(saga.WithObserver(obs))
+
+	s.AddStep(saga.Step{
+		Name:       "a",
+		Execute:    func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error { return nil },
+	})
+	s.AddStep(saga.Step{
+		Name:       "b",
+		Execute:    func(ctx context.Context) error { return errors.New("b failed") },
+		Compensate: func(ctx context.Context) error { return nil },
+	})
+
+	require.Error(t, s.Execute(context.Background()))
+	assert.Equal(t, []string{
+		"start:a", "end:a",
+		"start:b", "end:b",
+		"compensate-start:a", "compensate-end:a",
+		"saga-end",
+	}, obs.events)
+}
+
+func TestWithEventChannelEmitsSagaEvents(t *testing.T) {
+	events := make(chan saga.SagaEvent, 16)
+	s, _ := saga.New(saga.WithEventChannel(events))
+
+	s.AddStep(saga.Step{
+		Name:       "a",
+		Execute:    func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error { return nil },
+	})
+
+	require.NoError(t, s.Execute(context.Background()))
+	close(events)
+
+	var types []saga.SagaEventType
+	for evt := range events {
+		types = append(types, evt.Type)
+	}
+	assert.Equal(t, []saga.SagaEventType{saga.EventStepStart, saga.EventStepEnd, saga.EventSagaEnd}, types)
+}
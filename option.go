@@ -0,0 +1,37 @@
+package saga
+
+// Option configures a Saga created by New.
+type Option func(*sagaImpl)
+
+// WithLog sets the durable SagaLog a saga appends its messages to. If
+// omitted, New uses a fresh InMemorySagaLog, which does not survive a
+// process restart.
+func WithLog(log SagaLog) Option {
+	return func(s *sagaImpl) {
+		s.log = log
+	}
+}
+
+// WithSagaID sets the saga's ID, which is the key used to look it up in
+// the SagaLog. If omitted, New generates a random one.
+func WithSagaID(id string) Option {
+	return func(s *sagaImpl) {
+		s.sagaID = id
+	}
+}
+
+// WithObserver registers an Observer to receive step and compensation
+// lifecycle callbacks. It may be passed more than once; observers are
+// called in the order they were registered.
+func WithObserver(o Observer) Option {
+	return func(s *sagaImpl) {
+		s.observer = addObserver(s.observer, o)
+	}
+}
+
+// WithEventChannel registers an Observer that emits a SagaEvent on events
+// for every lifecycle callback. Sends are non-blocking: a full channel
+// drops the event rather than stalling the saga.
+func WithEventChannel(events chan<- SagaEvent) Option {
+	return WithObserver(channelObserver{events: events})
+}
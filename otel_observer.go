@@ -0,0 +1,125 @@
+//go:build otel
+
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTelObserver is a built-in Observer that starts one span per saga and
+// one child span per step/compensate attempt, tagging each with
+// "saga.step.name" and "saga.step.attempt" and marking the span as errored
+// on failure. Register it with WithObserver.
+type OTelObserver struct {
+	tracer trace.Tracer
+
+	mu    sync.Mutex
+	sagas map[string]trace.Span
+	steps map[string]trace.Span
+}
+
+// NewOTelObserver creates an OTelObserver. If tracer is nil, it uses
+// otel.Tracer with this module's import path as the instrumentation name.
+func NewOTelObserver(tracer trace.Tracer) *OTelObserver {
+	if tracer == nil {
+		tracer = otel.Tracer("github.com/bhaweshksingh/saga-go")
+	}
+	return &OTelObserver{
+		tracer: tracer,
+		sagas:  make(map[string]trace.Span),
+		steps:  make(map[string]trace.Span),
+	}
+}
+
+func stepSpanKey(sagaID, stepName string, attempt int) string {
+	return fmt.Sprintf("%s/%s/%d", sagaID, stepName, attempt)
+}
+
+// sagaContext returns a context carrying the saga's root span, starting it
+// on first use.
+func (o *OTelObserver) sagaContext(ctx context.Context, sagaID string) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if span, ok := o.sagas[sagaID]; ok {
+		return trace.ContextWithSpan(ctx, span)
+	}
+
+	sagaCtx, span := o.tracer.Start(ctx, "saga", trace.WithAttributes(attribute.String("saga.id", sagaID)))
+	o.sagas[sagaID] = span
+	return sagaCtx
+}
+
+// OnStepStart implements Observer.
+func (o *OTelObserver) OnStepStart(ctx context.Context, sagaID, stepName string, attempt int) {
+	o.startStep(ctx, sagaID, "saga.step", stepName, attempt)
+}
+
+// OnStepEnd implements Observer.
+func (o *OTelObserver) OnStepEnd(ctx context.Context, sagaID, stepName string, attempt int, err error) {
+	o.endStep(sagaID, stepName, attempt, err)
+}
+
+// OnCompensateStart implements Observer.
+func (o *OTelObserver) OnCompensateStart(ctx context.Context, sagaID, stepName string, attempt int) {
+	o.startStep(ctx, sagaID, "saga.compensate", "compensate:"+stepName, attempt)
+}
+
+// OnCompensateEnd implements Observer.
+func (o *OTelObserver) OnCompensateEnd(ctx context.Context, sagaID, stepName string, attempt int, err error) {
+	o.endStep(sagaID, "compensate:"+stepName, attempt, err)
+}
+
+func (o *OTelObserver) startStep(ctx context.Context, sagaID, spanName, key string, attempt int) {
+	parentCtx := o.sagaContext(ctx, sagaID)
+	_, span := o.tracer.Start(parentCtx, spanName, trace.WithAttributes(
+		attribute.String("saga.step.name", key),
+		attribute.Int("saga.step.attempt", attempt),
+	))
+
+	o.mu.Lock()
+	o.steps[stepSpanKey(sagaID, key, attempt)] = span
+	o.mu.Unlock()
+}
+
+func (o *OTelObserver) endStep(sagaID, key string, attempt int, err error) {
+	spanKey := stepSpanKey(sagaID, key, attempt)
+
+	o.mu.Lock()
+	span, ok := o.steps[spanKey]
+	delete(o.steps, spanKey)
+	o.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// OnSagaEnd implements Observer.
+func (o *OTelObserver) OnSagaEnd(ctx context.Context, sagaID string, err error) {
+	o.mu.Lock()
+	span, ok := o.sagas[sagaID]
+	delete(o.sagas, sagaID)
+	o.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,126 @@
+package saga
+
+import "context"
+
+// TypedStep describes a saga step whose forward call (Do) and compensator
+// (Undo) share a concrete response type, so Undo always receives the exact
+// value Do produced instead of having to recover it from a closure. Build
+// one with NewStep and chain it into a Pipeline with NewPipeline/Then.
+type TypedStep[Req, Resp any] struct {
+	Name             string
+	Policy           StepPolicy
+	CompensatePolicy CompensatePolicy
+	Do               func(ctx context.Context, req Req) (Resp, error)
+	Undo             func(ctx context.Context, resp Resp) error
+}
+
+// NewStep creates a TypedStep. Do is required; Undo may be nil for steps
+// that need no compensation (e.g. a pure read).
+func NewStep[Req, Resp any](name string, do func(ctx context.Context, req Req) (Resp, error), undo func(ctx context.Context, resp Resp) error) TypedStep[Req, Resp] {
+	return TypedStep[Req, Resp]{Name: name, Do: do, Undo: undo}
+}
+
+// WithPolicy sets the step's retry/timeout policy and returns the step for chaining.
+func (t TypedStep[Req, Resp]) WithPolicy(policy StepPolicy) TypedStep[Req, Resp] {
+	t.Policy = policy
+	return t
+}
+
+// WithCompensatePolicy sets the step's compensation retry/timeout policy and
+// returns the step for chaining.
+func (t TypedStep[Req, Resp]) WithCompensatePolicy(policy CompensatePolicy) TypedStep[Req, Resp] {
+	t.CompensatePolicy = policy
+	return t
+}
+
+// Pipeline assembles TypedSteps into a linear Saga that threads each step's
+// typed response into the next step's request, and returns the final
+// step's response as Execute's typed result. Req is the type fed to the
+// first step; Final is the type produced by the last.
+type Pipeline[Req, Final any] struct {
+	sagaOpts []Option
+	build    func(s Saga, req Req) func() (Final, error)
+}
+
+// NewPipeline starts a Pipeline from its first step. opts are forwarded to
+// saga.New when the pipeline is executed, so callers can still attach a
+// SagaLog, Observer, or fixed SagaID for recovery.
+func NewPipeline[Req, Resp any](first TypedStep[Req, Resp], opts ...Option) *Pipeline[Req, Resp] {
+	return &Pipeline[Req, Resp]{
+		sagaOpts: opts,
+		build: func(s Saga, req Req) func() (Resp, error) {
+			var resp Resp
+			var doErr error
+			s.AddStep(Step{
+				Name:             first.Name,
+				Policy:           first.Policy,
+				CompensatePolicy: first.CompensatePolicy,
+				Execute: func(ctx context.Context) error {
+					resp, doErr = first.Do(ctx, req)
+					return doErr
+				},
+				Compensate: func(ctx context.Context) error {
+					if doErr != nil || first.Undo == nil {
+						return nil
+					}
+					return first.Undo(ctx, resp)
+				},
+			})
+			return func() (Resp, error) { return resp, doErr }
+		},
+	}
+}
+
+// Then appends step to p, feeding p's current output as step's request, and
+// returns a Pipeline whose Final type is step's response. Then is a
+// package-level function rather than a method because Go methods can't
+// introduce new type parameters.
+func Then[Req, Prev, Next any](p *Pipeline[Req, Prev], step TypedStep[Prev, Next]) *Pipeline[Req, Next] {
+	return &Pipeline[Req, Next]{
+		sagaOpts: p.sagaOpts,
+		build: func(s Saga, req Req) func() (Next, error) {
+			prevResult := p.build(s, req)
+
+			var resp Next
+			var doErr error
+			s.AddStep(Step{
+				Name:             step.Name,
+				Policy:           step.Policy,
+				CompensatePolicy: step.CompensatePolicy,
+				Execute: func(ctx context.Context) error {
+					// Unreachable in practice: Saga.Execute stops at the
+					// first failing step, so this only runs once prevResult
+					// has a value.
+					prev, prevErr := prevResult()
+					if prevErr != nil {
+						return prevErr
+					}
+					resp, doErr = step.Do(ctx, prev)
+					return doErr
+				},
+				Compensate: func(ctx context.Context) error {
+					if doErr != nil || step.Undo == nil {
+						return nil
+					}
+					return step.Undo(ctx, resp)
+				},
+			})
+			return func() (Next, error) { return resp, doErr }
+		},
+	}
+}
+
+// Execute runs the assembled saga with req as the first step's request and
+// returns the last step's typed response. On failure it returns the zero
+// Final value alongside the *SagaError (or other error) Saga.Execute
+// produced.
+func (p *Pipeline[Req, Final]) Execute(ctx context.Context, req Req) (Final, error) {
+	s, _ := New(p.sagaOpts...)
+	result := p.build(s, req)
+
+	if err := s.Execute(ctx); err != nil {
+		var zero Final
+		return zero, err
+	}
+	return result()
+}
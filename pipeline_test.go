@@ -0,0 +1,67 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineThreadsTypedResults(t *testing.T) {
+	var undone []string
+
+	reserve := saga.NewStep("reserve",
+		func(ctx context.Context, orderID string) (string, error) {
+			return "reservation-" + orderID, nil
+		},
+		func(ctx context.Context, reservationID string) error {
+			undone = append(undone, reservationID)
+			return nil
+		},
+	)
+	charge := saga.NewStep("charge",
+		func(ctx context.Context, reservationID string) (int, error) {
+			return len(reservationID), nil
+		},
+		func(ctx context.Context, amount int) error {
+			undone = append(undone, "refund")
+			return nil
+		},
+	)
+
+	pipeline := saga.Then(saga.NewPipeline(reserve), charge)
+
+	amount, err := pipeline.Execute(context.Background(), "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, len("reservation-order-1"), amount)
+	assert.Empty(t, undone)
+}
+
+func TestPipelineCompensatesOnLaterFailure(t *testing.T) {
+	var undone []string
+
+	reserve := saga.NewStep("reserve",
+		func(ctx context.Context, orderID string) (string, error) {
+			return "reservation-" + orderID, nil
+		},
+		func(ctx context.Context, reservationID string) error {
+			undone = append(undone, reservationID)
+			return nil
+		},
+	)
+	charge := saga.NewStep("charge",
+		func(ctx context.Context, reservationID string) (int, error) {
+			return 0, errors.New("card declined")
+		},
+		func(ctx context.Context, amount int) error { return nil },
+	)
+
+	pipeline := saga.Then(saga.NewPipeline(reserve), charge)
+
+	_, err := pipeline.Execute(context.Background(), "order-2")
+	require.Error(t, err)
+	assert.Equal(t, []string{"reservation-order-2"}, undone)
+}
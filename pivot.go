@@ -0,0 +1,105 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+)
+
+// StepKind declares a step's role in pivot-transaction semantics: whether
+// it can still be compensated, whether it's the saga's single pivot, or
+// whether it must be retried forward instead of compensated.
+type StepKind int
+
+const (
+	// Compensatable steps can be undone by Compensate and must precede the
+	// Pivot, if any. It is the zero value, matching prior behavior.
+	Compensatable StepKind = iota
+	// Pivot is the point of no return: once it commits, the saga can no
+	// longer be compensated, only retried forward. A saga has at most one.
+	Pivot
+	// Retryable steps run after the Pivot. Their StepPolicy is expected to
+	// retry until success, since a Retryable failure is no longer
+	// compensated.
+	Retryable
+)
+
+// Compile validates that steps are ordered for pivot-transaction
+// semantics: every Compensatable step must precede the single optional
+// Pivot, and every Retryable step must follow it. A saga with no Pivot (the
+// all-Compensatable case) compiles trivially, matching plain reverse-order
+// compensation. Execute does not call Compile itself; callers composing
+// Pivot or Retryable steps should call it once up front.
+func Compile(steps []Step) error {
+	pivotSeen := false
+	pivotAt := -1
+
+	for i, step := range steps {
+		switch step.Kind {
+		case Compensatable:
+			if pivotSeen {
+				return fmt.Errorf("step %d (%q) is Compensatable but follows the pivot at step %d", i, step.Name, pivotAt)
+			}
+		case Pivot:
+			if pivotSeen {
+				return fmt.Errorf("step %d (%q) is a second pivot; a saga may have at most one", i, step.Name)
+			}
+			pivotSeen = true
+			pivotAt = i
+		case Retryable:
+			if !pivotSeen {
+				return fmt.Errorf("step %d (%q) is Retryable but precedes the pivot", i, step.Name)
+			}
+		default:
+			return fmt.Errorf("step %d (%q) has unknown Kind %d", i, step.Name, step.Kind)
+		}
+	}
+
+	return nil
+}
+
+// pivotIndex returns the index of s's Pivot step, or -1 if it has none.
+func (s *sagaImpl) pivotIndex() int {
+	for i, step := range s.steps {
+		if step.Kind == Pivot {
+			return i
+		}
+	}
+	return -1
+}
+
+// isPastPivot reports whether step i runs after the saga's pivot, meaning
+// its failure must not trigger compensation: the pivot already committed,
+// so only a forward retry (via i's own StepPolicy) can resolve it.
+func (s *sagaImpl) isPastPivot(i int) bool {
+	pivotAt := s.pivotIndex()
+	return pivotAt >= 0 && i > pivotAt
+}
+
+// pivotCompleted reports whether s's Pivot step, if any, is among its
+// completed steps, meaning compensation is no longer allowed: the pivot
+// has already committed.
+func (s *sagaImpl) pivotCompleted() bool {
+	pivotAt := s.pivotIndex()
+	if pivotAt < 0 {
+		return false
+	}
+	for _, i := range s.completedSteps {
+		if i == pivotAt {
+			return true
+		}
+	}
+	return false
+}
+
+// unlockCompleted calls Unlock for every completed step that declared one.
+// It runs once, when the saga as a whole commits: a completed step locked
+// via Lock stays locked until either this point or its own compensation,
+// whichever comes first. (A step whose Execute fails is unlocked by
+// executeStep instead.)
+func (s *sagaImpl) unlockCompleted(ctx context.Context) {
+	for _, i := range s.completedSteps {
+		if unlock := s.steps[i].Unlock; unlock != nil {
+			_ = unlock(ctx)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	t.Run("all compensatable compiles", func(t *testing.T) {
+		err := saga.Compile([]saga.Step{{Name: "a"}, {Name: "b"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("compensatable after pivot is rejected", func(t *testing.T) {
+		err := saga.Compile([]saga.Step{
+			{Name: "a", Kind: saga.Pivot},
+			{Name: "b", Kind: saga.Compensatable},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "follows the pivot")
+	})
+
+	t.Run("retryable before pivot is rejected", func(t *testing.T) {
+		err := saga.Compile([]saga.Step{
+			{Name: "a", Kind: saga.Retryable},
+			{Name: "b", Kind: saga.Pivot},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "precedes the pivot")
+	})
+
+	t.Run("second pivot is rejected", func(t *testing.T) {
+		err := saga.Compile([]saga.Step{
+			{Name: "a", Kind: saga.Pivot},
+			{Name: "b", Kind: saga.Pivot},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "second pivot")
+	})
+
+	t.Run("valid compensatable/pivot/retryable sequence compiles", func(t *testing.T) {
+		err := saga.Compile([]saga.Step{
+			{Name: "a", Kind: saga.Compensatable},
+			{Name: "b", Kind: saga.Pivot},
+			{Name: "c", Kind: saga.Retryable},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestPivotStopsCompensationOnLaterFailure(t *testing.T) {
+	s, _ := saga.New()
+	compensated := []string{}
+
+	s.AddStep(saga.Step{
+		Name:       "reserve",
+		Kind:       saga.Compensatable,
+		Execute:    func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error { compensated = append(compensated, "reserve"); return nil },
+	})
+	s.AddStep(saga.Step{
+		Name:       "charge",
+		Kind:       saga.Pivot,
+		Execute:    func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error { compensated = append(compensated, "charge"); return nil },
+	})
+	s.AddStep(saga.Step{
+		Name:       "notify",
+		Kind:       saga.Retryable,
+		Execute:    func(ctx context.Context) error { return errors.New("notify failed") },
+		Compensate: func(ctx context.Context) error { compensated = append(compensated, "notify"); return nil },
+	})
+
+	err := s.Execute(context.Background())
+	require.Error(t, err)
+	assert.Empty(t, compensated, "no compensation should run once the pivot has committed")
+}
+
+func TestPivotStopsCompensationOnAbort(t *testing.T) {
+	s, abortFunc := saga.New()
+	compensated := []string{}
+
+	s.AddStep(saga.Step{
+		Name:       "reserve",
+		Kind:       saga.Compensatable,
+		Execute:    func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error { compensated = append(compensated, "reserve"); return nil },
+	})
+	s.AddStep(saga.Step{
+		Name:       "charge",
+		Kind:       saga.Pivot,
+		Execute:    func(ctx context.Context) error { return nil },
+		Compensate: func(ctx context.Context) error { compensated = append(compensated, "charge"); return nil },
+	})
+	s.AddStep(saga.Step{
+		// notify blocks until aborted, so the pivot has already committed
+		// when abortFunc fires.
+		Name: "notify",
+		Kind: saga.Retryable,
+		Execute: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		Compensate: func(ctx context.Context) error { compensated = append(compensated, "notify"); return nil },
+	})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		var abortErr error
+		abortFunc(context.Background(), &abortErr)
+	}()
+
+	err := s.Execute(context.Background())
+	require.Error(t, err)
+	assert.Empty(t, compensated, "pivot already committed; abort must not compensate it or anything before it")
+}
+
+func TestLockUnlockLifecycle(t *testing.T) {
+	t.Run("unlocked on commit", func(t *testing.T) {
+		s, _ := saga.New()
+		var locked, unlocked bool
+
+		s.AddStep(saga.Step{
+			Execute:    func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error { return nil },
+			Lock:       func(ctx context.Context) error { locked = true; return nil },
+			Unlock:     func(ctx context.Context) error { unlocked = true; return nil },
+		})
+
+		require.NoError(t, s.Execute(context.Background()))
+		assert.True(t, locked)
+		assert.True(t, unlocked)
+	})
+
+	t.Run("unlocked on compensate", func(t *testing.T) {
+		s, _ := saga.New()
+		var unlocked bool
+
+		s.AddStep(saga.Step{
+			Execute:    func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error { return nil },
+			Lock:       func(ctx context.Context) error { return nil },
+			Unlock:     func(ctx context.Context) error { unlocked = true; return nil },
+		})
+		s.AddStep(saga.Step{
+			Execute:    func(ctx context.Context) error { return errors.New("second step failed") },
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.True(t, unlocked)
+	})
+
+	t.Run("lock failure fails the step without running Execute", func(t *testing.T) {
+		s, _ := saga.New()
+		var executed bool
+
+		s.AddStep(saga.Step{
+			Execute:    func(ctx context.Context) error { executed = true; return nil },
+			Compensate: func(ctx context.Context) error { return nil },
+			Lock:       func(ctx context.Context) error { return errors.New("already locked") },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.False(t, executed)
+	})
+
+	t.Run("unlocked when Execute itself fails", func(t *testing.T) {
+		s, _ := saga.New()
+		var unlocked bool
+
+		s.AddStep(saga.Step{
+			Execute:    func(ctx context.Context) error { return errors.New("execute failed") },
+			Compensate: func(ctx context.Context) error { return nil },
+			Lock:       func(ctx context.Context) error { return nil },
+			Unlock:     func(ctx context.Context) error { unlocked = true; return nil },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.True(t, unlocked, "Lock succeeded but Execute failed before completedSteps, so Unlock must fire here")
+	})
+}
@@ -0,0 +1,171 @@
+package saga
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// BackoffKind selects how Backoff.Duration grows between retry attempts.
+type BackoffKind int
+
+const (
+	// BackoffConstant waits Base between every attempt.
+	BackoffConstant BackoffKind = iota
+	// BackoffExponential waits Base * 2^(attempt-1), capped at Max.
+	BackoffExponential
+)
+
+// Backoff controls the wait between retry attempts.
+type Backoff struct {
+	Kind BackoffKind
+	Base time.Duration
+	// Max caps the computed wait; zero means no cap.
+	Max time.Duration
+	// Jitter, if true, scales the computed wait by a random factor in
+	// [0.5, 1.5) to avoid synchronized retries across sagas.
+	Jitter bool
+}
+
+// Duration returns how long to wait before retry attempt n (n is the
+// attempt that just failed, so the first retry is Duration(1)).
+func (b Backoff) Duration(attempt int) time.Duration {
+	if b.Base <= 0 || attempt < 1 {
+		return 0
+	}
+
+	wait := b.Base
+	if b.Kind == BackoffExponential {
+		wait = b.Base << (attempt - 1)
+	}
+	if b.Max > 0 && wait > b.Max {
+		wait = b.Max
+	}
+	if b.Jitter {
+		wait = time.Duration(float64(wait) * (0.5 + rand.Float64()))
+	}
+	return wait
+}
+
+// StepPolicy controls retries, per-attempt timeouts, and which errors are
+// worth retrying for a step's forward call.
+type StepPolicy struct {
+	// Timeout bounds a single attempt via context.WithTimeout. Zero means
+	// the parent context's deadline (if any) is used as-is.
+	Timeout time.Duration
+	// MaxAttempts is the total number of attempts, including the first.
+	// Zero or one means no retry, matching the behavior before policies
+	// existed.
+	MaxAttempts int
+	// Backoff is waited between attempts.
+	Backoff Backoff
+	// RetryOn decides whether a given error should be retried. Nil means
+	// retry every error up to MaxAttempts.
+	RetryOn func(error) bool
+}
+
+// CompensatePolicy is the compensation-side counterpart of StepPolicy.
+// Compensations are expected to be effectively-once, so it's normal for a
+// CompensatePolicy to retry harder (more attempts, longer backoff) than
+// the matching StepPolicy.
+type CompensatePolicy StepPolicy
+
+// runWithPolicy runs fn, retrying on failure per policy. onStart is called
+// immediately before each attempt (1-based), onEnd immediately after, so
+// callers can log/trace each attempt individually.
+func runWithPolicy(ctx context.Context, policy StepPolicy, onStart func(attempt int), onEnd func(attempt int, err error), fn func(ctx context.Context) error) error {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		onStart(attempt)
+
+		callCtx := ctx
+		var cancel context.CancelFunc
+		if policy.Timeout > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		}
+		err := fn(callCtx)
+		if cancel != nil {
+			cancel()
+		}
+		onEnd(attempt, err)
+
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if attempt == maxAttempts {
+			break
+		}
+		if policy.RetryOn != nil && !policy.RetryOn(err) {
+			break
+		}
+
+		wait := policy.Backoff.Duration(attempt)
+		if wait <= 0 {
+			continue
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return context.Cause(ctx)
+		}
+	}
+
+	return lastErr
+}
+
+// executeStep runs step i's Execute under its StepPolicy, logging a
+// StartTask message per attempt, notifying the saga's Observer of each
+// attempt's start and end, and logging an EndTask message on success.
+func (s *sagaImpl) executeStep(ctx context.Context, i int) error {
+	step := s.steps[i]
+	if step.Lock != nil {
+		if err := step.Lock(ctx); err != nil {
+			return err
+		}
+	}
+	err := runWithPolicy(ctx, step.Policy, func(attempt int) {
+		s.logMessage(newAttemptMessage(s.sagaID, MsgStartTask, stepID(i), attempt))
+		s.observer.OnStepStart(ctx, s.sagaID, step.Name, attempt)
+	}, func(attempt int, err error) {
+		s.observer.OnStepEnd(ctx, s.sagaID, step.Name, attempt, err)
+	}, step.Execute)
+	if err == nil {
+		s.logMessage(newMessage(s.sagaID, MsgEndTask, stepID(i), nil))
+	} else if step.Lock != nil && step.Unlock != nil {
+		// Execute never reached completedSteps, so neither compensateStep
+		// nor unlockCompleted will ever see this step: Unlock it ourselves
+		// or the Lock above leaks for good.
+		_ = step.Unlock(ctx)
+	}
+	return err
+}
+
+// compensateStep runs step i's Compensate under its CompensatePolicy,
+// logging a StartCompensate message per attempt, notifying the saga's
+// Observer of each attempt's start and end, and logging an EndCompensate
+// message on success.
+func (s *sagaImpl) compensateStep(ctx context.Context, i int) error {
+	step := s.steps[i]
+	err := runWithPolicy(ctx, StepPolicy(step.CompensatePolicy), func(attempt int) {
+		s.logMessage(newAttemptMessage(s.sagaID, MsgStartCompensate, stepID(i), attempt))
+		s.observer.OnCompensateStart(ctx, s.sagaID, step.Name, attempt)
+	}, func(attempt int, err error) {
+		s.observer.OnCompensateEnd(ctx, s.sagaID, step.Name, attempt, err)
+	}, step.Compensate)
+	if err == nil {
+		s.logMessage(newMessage(s.sagaID, MsgEndCompensate, stepID(i), nil))
+		if step.Unlock != nil {
+			_ = step.Unlock(ctx)
+		}
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bhaweshksingh/saga-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepPolicyRetry(t *testing.T) {
+	t.Run("retries up to MaxAttempts then succeeds", func(t *testing.T) {
+		s, _ := saga.New()
+		attempts := 0
+
+		s.AddStep(saga.Step{
+			Policy: saga.StepPolicy{MaxAttempts: 3},
+			Execute: func(ctx context.Context) error {
+				attempts++
+				if attempts < 3 {
+					return errors.New("transient")
+				}
+				return nil
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		require.NoError(t, s.Execute(context.Background()))
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after MaxAttempts", func(t *testing.T) {
+		s, _ := saga.New()
+		attempts := 0
+
+		s.AddStep(saga.Step{
+			Policy: saga.StepPolicy{MaxAttempts: 2},
+			Execute: func(ctx context.Context) error {
+				attempts++
+				return errors.New("always fails")
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("RetryOn can stop retries early", func(t *testing.T) {
+		s, _ := saga.New()
+		attempts := 0
+
+		s.AddStep(saga.Step{
+			Policy: saga.StepPolicy{
+				MaxAttempts: 5,
+				RetryOn:     func(err error) bool { return false },
+			},
+			Execute: func(ctx context.Context) error {
+				attempts++
+				return errors.New("not retryable")
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("per-attempt timeout fires independently of parent context", func(t *testing.T) {
+		s, _ := saga.New()
+		attempts := 0
+
+		s.AddStep(saga.Step{
+			Policy: saga.StepPolicy{MaxAttempts: 1, Timeout: 10 * time.Millisecond},
+			Execute: func(ctx context.Context) error {
+				attempts++
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("CompensatePolicy retries compensation", func(t *testing.T) {
+		s, _ := saga.New()
+		compensateAttempts := 0
+
+		s.AddStep(saga.Step{
+			CompensatePolicy: saga.CompensatePolicy{MaxAttempts: 3},
+			Execute:          func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error {
+				compensateAttempts++
+				if compensateAttempts < 3 {
+					return errors.New("transient compensate failure")
+				}
+				return nil
+			},
+		})
+		s.AddStep(saga.Step{
+			Execute:    func(ctx context.Context) error { return errors.New("step 2 failed") },
+			Compensate: func(ctx context.Context) error { return nil },
+		})
+
+		err := s.Execute(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, 3, compensateAttempts)
+	})
+}
+
+func TestBackoffDuration(t *testing.T) {
+	b := saga.Backoff{Kind: saga.BackoffExponential, Base: 10 * time.Millisecond, Max: 35 * time.Millisecond}
+	assert.Equal(t, 10*time.Millisecond, b.Duration(1))
+	assert.Equal(t, 20*time.Millisecond, b.Duration(2))
+	assert.Equal(t, 35*time.Millisecond, b.Duration(3), "should be capped at Max")
+}
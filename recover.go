@@ -0,0 +1,74 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Recover rebuilds the completed-steps state of sagaID from log and, if the
+// saga ended mid-flight (no EndSaga/AbortSaga message was ever written),
+// resumes compensation of whatever steps completed but were never
+// compensated. It is a no-op if the saga already reached a terminal state.
+//
+// steps must be the same step list (in the same order) the saga was
+// originally built with, since step identity is tracked by index in the
+// log entries written by Execute/Abort.
+func Recover(ctx context.Context, log SagaLog, sagaID string, steps []Step) error {
+	messages, err := log.GetMessages(sagaID)
+	if err != nil {
+		return fmt.Errorf("recover saga %s: %w", sagaID, err)
+	}
+
+	completed := make(map[int]bool)
+	compensated := make(map[int]bool)
+	ended := false
+
+	for _, msg := range messages {
+		idx, convErr := stepIndexFromID(msg.StepID)
+		switch msg.Type {
+		case MsgEndTask:
+			if convErr == nil {
+				completed[idx] = true
+			}
+		case MsgEndCompensate:
+			if convErr == nil {
+				compensated[idx] = true
+			}
+		case MsgEndSaga, MsgAbortSaga:
+			ended = true
+		}
+	}
+
+	if ended {
+		return nil
+	}
+
+	s := &sagaImpl{
+		sagaID:   sagaID,
+		steps:    steps,
+		log:      log,
+		observer: noopObserver{},
+	}
+	if hasDependencies(steps) {
+		if _, order, err := resolveDependencyGraph(steps); err == nil {
+			s.order = order
+		}
+	}
+	for idx := range completed {
+		if idx < len(steps) && !compensated[idx] {
+			s.completedSteps = append(s.completedSteps, idx)
+		}
+	}
+	sort.Ints(s.completedSteps)
+
+	return s.Abort(ctx)
+}
+
+// GetActiveSagas returns the IDs of every saga in log that has not yet
+// reached a terminal (EndSaga/AbortSaga) state. It is a thin convenience
+// wrapper so callers don't need to reach into the SagaLog directly when
+// writing a recovery sweep at process startup.
+func GetActiveSagas(log SagaLog) ([]string, error) {
+	return log.GetActiveSagas()
+}
@@ -20,10 +20,36 @@ type Saga interface {
 
 // Step represents a single step in a saga, including its execution and compensation logic.
 type Step struct {
+	// Name identifies the step for logging and for other steps' DependsOn.
+	// It is optional; steps without dependencies don't need one.
+	Name string
+	// DependsOn lists the Names of steps that must complete before this
+	// one runs. If empty, the step runs in the saga's declared order as
+	// before. Any non-empty DependsOn anywhere in the saga switches
+	// Execute from sequential to DAG scheduling for every step.
+	DependsOn []string
+	// Policy controls retries, timeout, and backoff for Execute. The zero
+	// value runs Execute exactly once, matching prior behavior.
+	Policy StepPolicy
+	// CompensatePolicy is Policy's counterpart for Compensate.
+	CompensatePolicy CompensatePolicy
+	// Kind declares this step's role in pivot-transaction semantics. The
+	// zero value, Compensatable, matches prior behavior. See Compile.
+	Kind StepKind
+
 	// Execute is the main logic for this step.
 	Execute func(ctx context.Context) error
 	// Compensate is called to undo this step if a later step fails.
 	Compensate func(ctx context.Context) error
+
+	// Lock is called once, before Execute's first attempt, so callers can
+	// set a record-level "pending" flag that other sagas can observe
+	// (semantic locking). Unlock is called once that flag is safe to
+	// clear: after the saga as a whole commits, after this step is
+	// compensated, or right after Execute fails if Lock succeeded,
+	// whichever happens first. Both are optional.
+	Lock   func(ctx context.Context) error
+	Unlock func(ctx context.Context) error
 }
 
 type sagaState int
@@ -42,13 +68,42 @@ type sagaImpl struct {
 	state          sagaState
 	steps          []Step
 	completedSteps []int
+	// order is the topological order steps ran in: identity (0..n-1) for
+	// the plain sequential path, or the resolved DAG order otherwise. It
+	// is what compensate and Abort reverse to undo completed steps.
+	order []int
+
+	sagaID   string
+	log      SagaLog
+	observer Observer
+	// cancel stops the context.Context Execute's steps and compensations
+	// run under, recording why via context.Cause: ErrManualAbort for a
+	// manual Abort, ErrStepFailed for a step failure. It is set for the
+	// duration of Execute and nil before/after.
+	cancel context.CancelCauseFunc
+	// done is closed, and result set, when Execute returns. It lets a
+	// concurrent Abort call that finds Execute already running wait for
+	// Execute's own compensation (triggered by cancel) instead of racing
+	// it with a second one.
+	done   chan struct{}
+	result error
 }
 
-// New creates a new Saga instance.
-func New() (Saga, func(context.Context, *error)) {
+// New creates a new Saga instance. By default it is logged to a fresh
+// InMemorySagaLog under a random ID; pass WithLog and WithSagaID to make
+// the saga durable across process restarts (see Recover).
+func New(opts ...Option) (Saga, func(context.Context, *error)) {
 	s := sagaImpl{
 		steps:          []Step{},
 		completedSteps: []int{},
+		log:            NewInMemorySagaLog(),
+		observer:       noopObserver{},
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.sagaID == "" {
+		s.sagaID = newSagaID()
 	}
 
 	abort := func(ctx context.Context, err *error) {
@@ -60,16 +115,41 @@ func New() (Saga, func(context.Context, *error)) {
 }
 
 // Abort stops the saga execution and triggers compensation for completed steps.
-func (s *sagaImpl) Abort(ctx context.Context) error {
+func (s *sagaImpl) Abort(ctx context.Context) (err error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.state == stateFinished || s.state == stateAborting {
+		s.mu.Unlock()
 		return nil
 	}
+	running := s.state == stateFinishing
 	s.state = stateAborting
+	s.logMessage(newMessage(s.sagaID, MsgAbortSaga, "", nil))
+	if s.cancel != nil {
+		s.cancel(ErrManualAbort)
+	}
+	done := s.done
+	s.mu.Unlock()
+
+	if running {
+		// Execute is in flight on another goroutine, or has already
+		// returned with an error (a failed Execute leaves the state at
+		// stateFinishing). Cancelling it above is what actually stops it
+		// and runs its own compensation; running a second compensation
+		// pass here would race or repeat it. Wait for Execute to return
+		// and surface its result instead.
+		select {
+		case <-done:
+			s.mu.Lock()
+			result := s.result
+			s.mu.Unlock()
+			return result
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	defer func() { s.observer.OnSagaEnd(ctx, s.sagaID, err) }()
 
-	// If the saga is already finished or compensation is in progress, do nothing
 	if len(s.completedSteps) == 0 {
 		return nil
 	}
@@ -77,15 +157,13 @@ func (s *sagaImpl) Abort(ctx context.Context) error {
 	var compensationErrs []string
 	contextCancelled := false
 
-	for i := len(s.completedSteps) - 1; i >= 0; i-- {
-		stepIndex := s.completedSteps[i]
-
+	for _, stepIndex := range s.completedInReverseOrder() {
 		select {
 		case <-ctx.Done():
-			compensationErrs = append(compensationErrs, fmt.Sprintf("context cancelled during abort: %v", ctx.Err()))
+			compensationErrs = append(compensationErrs, fmt.Sprintf("context cancelled during abort: %v", context.Cause(ctx)))
 			contextCancelled = true
 		default:
-			if err := s.steps[stepIndex].Compensate(ctx); err != nil {
+			if err := s.compensateStep(ctx, stepIndex); err != nil {
 				compensationErrs = append(compensationErrs, fmt.Sprintf("step %d: %v", stepIndex, err))
 			}
 		}
@@ -107,31 +185,94 @@ func (s *sagaImpl) Abort(ctx context.Context) error {
 	return nil
 }
 
-// Execute implements the Saga interface.
-func (s *sagaImpl) Execute(ctx context.Context) error {
+// Execute implements the Saga interface. Like Abort, it does not hold s.mu
+// for its whole duration: a concurrent Abort call needs the lock to signal
+// cancellation and must not be blocked behind steps that are still running.
+func (s *sagaImpl) Execute(ctx context.Context) (err error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.state != stateInitial {
+		s.mu.Unlock()
 		return nil
 	}
 	s.state = stateFinishing
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.result = err
+		s.cancel = nil
+		s.mu.Unlock()
+		// OnSagaEnd must run before close(s.done): a concurrent Abort
+		// waiting on done returns s.result to its own caller as soon as
+		// done closes, so callers must be able to assume OnSagaEnd has
+		// already fired by then.
+		s.observer.OnSagaEnd(ctx, s.sagaID, err)
+		close(s.done)
+	}()
+
+	if err := s.log.StartSaga(s.sagaID, nil); err != nil {
+		return fmt.Errorf("start saga %s: %w", s.sagaID, err)
+	}
 
-	for i, step := range s.steps {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			if err := step.Execute(ctx); err != nil {
-				return s.compensate(ctx, i, err)
+	runCtx, cancel := context.WithCancelCause(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.mu.Unlock()
+	defer cancel(nil)
+
+	if hasDependencies(s.steps) {
+		dependsOn, order, depErr := resolveDependencyGraph(s.steps)
+		if depErr != nil {
+			return depErr
+		}
+		s.order = order
+		if execErr := s.executeGraph(runCtx, dependsOn, order); execErr != nil {
+			return execErr
+		}
+	} else {
+		s.order = make([]int, len(s.steps))
+		for i := range s.steps {
+			s.order[i] = i
+		}
+
+		for i := range s.steps {
+			select {
+			case <-runCtx.Done():
+				cause := context.Cause(runCtx)
+				if s.isPastPivot(i) {
+					return cause
+				}
+				return s.compensate(runCtx, i, cause)
+			default:
+				if err := s.executeStep(runCtx, i); err != nil {
+					cancel(ErrStepFailed{Index: i, Err: err})
+					if s.isPastPivot(i) {
+						return err
+					}
+					return s.compensate(runCtx, i, err)
+				}
+				s.completedSteps = append(s.completedSteps, i)
 			}
-			s.completedSteps = append(s.completedSteps, i)
 		}
 	}
 
+	s.unlockCompleted(runCtx)
+	s.mu.Lock()
+	s.state = stateFinished
+	s.mu.Unlock()
+	s.logMessage(newMessage(s.sagaID, MsgEndSaga, "", nil))
+
 	return nil
 }
 
+// logMessage appends msg to the saga's log, swallowing the error: a logging
+// failure must not itself abort the in-memory saga, since the log exists to
+// help crash recovery, not to gate forward progress.
+func (s *sagaImpl) logMessage(msg SagaMessage) {
+	_ = s.log.LogMessage(msg)
+}
+
 // AddStep implements the Saga interface.
 func (s *sagaImpl) AddStep(step Step) {
 	s.mu.Lock()
@@ -142,23 +283,26 @@ func (s *sagaImpl) AddStep(step Step) {
 	}
 }
 
-// compensate runs compensation logic for all completed steps in reverse order.
+// compensate undoes every completed step in reverse topological order. A
+// negative failedStep (used by the DAG scheduler, where "before the failed
+// step" isn't well defined) compensates every completed step; otherwise
+// only steps that ran strictly before failedStep are compensated, matching
+// the sequential path's ordering guarantee.
+//
+// Unlike Abort, compensate does not bail out when ctx is done: ctx is
+// routinely already cancelled by the time compensate runs, since the step
+// failure that triggers it also sets context.Cause via the saga's cancel.
+// A Compensate callback that cares why can still inspect context.Cause(ctx).
 func (s *sagaImpl) compensate(ctx context.Context, failedStep int, origErr error) error {
 	var compensationErrs []string
 
-	for i := len(s.completedSteps) - 1; i >= 0; i-- {
-		stepIndex := s.completedSteps[i]
-		if stepIndex >= failedStep {
+	for _, stepIndex := range s.completedInReverseOrder() {
+		if failedStep >= 0 && stepIndex >= failedStep {
 			continue
 		}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			if err := s.steps[stepIndex].Compensate(ctx); err != nil {
-				compensationErrs = append(compensationErrs, fmt.Sprintf("step %d: %v", stepIndex, err))
-			}
+		if err := s.compensateStep(ctx, stepIndex); err != nil {
+			compensationErrs = append(compensationErrs, fmt.Sprintf("step %d: %v", stepIndex, err))
 		}
 	}
 
@@ -190,6 +190,20 @@ func TestSaga(t *testing.T) {
 			},
 		})
 
+		// step3 blocks until aborted, so Execute is still in flight (and
+		// steps 1-2 have already completed) when abortFunc fires.
+		s.AddStep(saga.Step{
+			Execute: func(ctx context.Context) error {
+				steps = append(steps, "step3")
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Compensate: func(ctx context.Context) error {
+				steps = append(steps, "compensate3")
+				return nil
+			},
+		})
+
 		backgroundCtx := context.Background()
 		go func() {
 			time.Sleep(50 * time.Millisecond)
@@ -199,9 +213,9 @@ func TestSaga(t *testing.T) {
 
 		err := s.Execute(backgroundCtx)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "transaction rollback")
 		assert.Contains(t, steps, "compensate1")
 		assert.Contains(t, steps, "compensate2")
+		assert.NotContains(t, steps, "compensate3", "step3 never completed, so it should not be compensated")
 	})
 
 	t.Run("Adding step after execution", func(t *testing.T) {